@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/pogorskii/tmdb-movies-data/jobstore"
+	"github.com/pogorskii/tmdb-movies-data/moviedata"
+)
+
+// dailyExportURLLayout is TMDB's daily movie ID export, one gzipped file
+// per day named by date; it lags the current day by about 8 hours (it's
+// generated once a day), so runDailyExportMode looks at yesterday's file.
+const dailyExportURLLayout = "http://files.tmdb.org/p/exports/movie_ids_01_02_2006.json.gz"
+
+// runDailyExportMode downloads TMDB's daily movie ID export and enqueues
+// any IDs not already known to js (pending, in_flight, done, or failed
+// from a previous run), so a scheduled daily run only ever processes
+// catalog growth instead of re-walking every movie TMDB has.
+func runDailyExportMode(ctx context.Context, js *jobstore.JobStore) error {
+	url := time.Now().Add(-24 * time.Hour).Format(dailyExportURLLayout)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("daily-export: build request: %w", err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("daily-export: fetch %s: %w", url, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("daily-export: unexpected HTTP status code fetching %s: %d", url, res.StatusCode)
+	}
+
+	gz, err := gzip.NewReader(res.Body)
+	if err != nil {
+		return fmt.Errorf("daily-export: open gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	// The export is newline-delimited JSON, one movie object per line, not
+	// a JSON array, so it's scanned line by line instead of unmarshalled
+	// in one shot.
+	var ids []int
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var movieID moviedata.MovieID
+		if err := json.Unmarshal(line, &movieID); err != nil {
+			return fmt.Errorf("daily-export: decode line: %w", err)
+		}
+		ids = append(ids, movieID.ID)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("daily-export: read export: %w", err)
+	}
+
+	// EnqueueMovieIDs already ignores IDs the job store has seen before,
+	// so this is the diff against previously seen IDs the export implies.
+	if err := js.EnqueueMovieIDs(ids); err != nil {
+		return fmt.Errorf("daily-export: enqueue IDs: %w", err)
+	}
+
+	slog.Info("daily-export: enqueued export", "source_url", url, "movie_count", len(ids))
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import "strings"
+
+// includable sub-resources that can be requested via --include, gated
+// independently of the always-on release_dates/credits append.
+const (
+	includeImages            = "images"
+	includeVideos            = "videos"
+	includeKeywords          = "keywords"
+	includeExternalIDs       = "external_ids"
+	includeAlternativeTitles = "alternative_titles"
+)
+
+// enabledIncludes is the set of sub-resources requested via --include,
+// populated once in main before any worker starts.
+var enabledIncludes = map[string]bool{}
+
+// parseIncludes turns a comma-separated --include flag value (e.g.
+// "images,videos,keywords") into the lookup enabledIncludes uses.
+func parseIncludes(raw string) map[string]bool {
+	includes := map[string]bool{}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			includes[name] = true
+		}
+	}
+	return includes
+}
+
+// appendToResponseParam builds the append_to_response query value: the
+// sub-resources the tool always needs, plus whatever enabledIncludes adds.
+func appendToResponseParam() string {
+	parts := []string{"release_dates", "credits"}
+	for _, name := range []string{includeImages, includeVideos, includeKeywords, includeExternalIDs, includeAlternativeTitles} {
+		if enabledIncludes[name] {
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/pogorskii/tmdb-movies-data/jobstore"
+)
+
+// tmdbDateLayout is the date-only format TMDB's changes endpoint expects
+// and returns (YYYY-MM-DD).
+const tmdbDateLayout = "2006-01-02"
+
+// changesLookback bounds how far back the very first incremental run looks;
+// TMDB caps start_date/end_date ranges at 14 days.
+const changesLookback = 14 * 24 * time.Hour
+
+// Watermark state keys, persisted in js's crawl_state table so an
+// incremental run can resume a killed multi-page fetch, or pick up where
+// the last completed run left off.
+const (
+	stateWatermark  = "incremental_watermark"    // end_date of the last fully-processed range
+	stateRangeStart = "incremental_range_start"  // start_date of an in-progress range, if any
+	stateRangeEnd   = "incremental_range_end"    // end_date of an in-progress range, if any
+	stateRangePage  = "incremental_range_page"   // last page fully enqueued in the in-progress range
+)
+
+// tmdbChangesResponse is the body of GET /3/movie/changes.
+type tmdbChangesResponse struct {
+	Results    []tmdbChangeEntry `json:"results"`
+	Page       int               `json:"page"`
+	TotalPages int               `json:"total_pages"`
+}
+
+type tmdbChangeEntry struct {
+	ID int `json:"id"`
+}
+
+// fetchChangesPage fetches one page of the movie changes feed, applying the
+// same rate limiting and retry-on-transient-failure behavior as
+// fetchMovieData.
+func fetchChangesPage(ctx context.Context, startDate, endDate string, page int) ([]byte, error) {
+	url := fmt.Sprintf("https://api.themoviedb.org/3/movie/changes?start_date=%s&end_date=%s&page=%d", startDate, endDate, page)
+	authorizationValue := fmt.Sprintf("Bearer %s", os.Getenv("API_ACCESS_TOKEN"))
+
+	var lastErr error
+	for attempt := 0; attempt < maxFetchAttempts; attempt++ {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Add("accept", "application/json")
+		req.Header.Add("Authorization", authorizationValue)
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			lastErr = err
+			time.Sleep(retryDelay(attempt))
+			continue
+		}
+
+		limiter.updateFromResponse(res)
+
+		if res.StatusCode == http.StatusOK {
+			body, err := io.ReadAll(res.Body)
+			res.Body.Close()
+			return body, err
+		}
+
+		res.Body.Close()
+
+		if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500 {
+			lastErr = fmt.Errorf("unexpected HTTP status code: %d", res.StatusCode)
+			time.Sleep(retryDelay(attempt))
+			continue
+		}
+
+		return nil, fmt.Errorf("unexpected HTTP status code: %d", res.StatusCode)
+	}
+
+	return nil, fmt.Errorf("changes page %d: giving up after %d attempts: %w", page, maxFetchAttempts, lastErr)
+}
+
+// runIncrementalMode discovers movies changed since the last successful
+// incremental run via TMDB's changes endpoint and enqueues their IDs
+// through js, the same job store the worker pool drains. The gap since
+// the last watermark is walked in ≤14-day windows (TMDB's own cap on
+// start_date/end_date), so a crawler that's been down for a while — the
+// exact scenario chunk0-1's durability is meant to support — catches up
+// in however many windows that takes instead of sending TMDB an
+// out-of-range request. It resumes a window left incomplete by a killed
+// previous run instead of restarting it.
+func runIncrementalMode(ctx context.Context, js *jobstore.JobStore) error {
+	for {
+		startDate, endDate, page, caughtUp, err := loadOrStartWindow(js)
+		if err != nil {
+			return err
+		}
+		if caughtUp {
+			return nil
+		}
+
+		for {
+			page++
+
+			body, err := fetchChangesPage(ctx, startDate, endDate, page)
+			if err != nil {
+				return fmt.Errorf("incremental: fetch page %d: %w", page, err)
+			}
+
+			var resp tmdbChangesResponse
+			if err := json.Unmarshal(body, &resp); err != nil {
+				return fmt.Errorf("incremental: decode page %d: %w", page, err)
+			}
+
+			ids := make([]int, len(resp.Results))
+			for i, entry := range resp.Results {
+				ids[i] = entry.ID
+			}
+			if err := js.EnqueueMovieIDs(ids); err != nil {
+				return fmt.Errorf("incremental: enqueue page %d: %w", page, err)
+			}
+
+			if err := js.SetState(stateRangePage, fmt.Sprintf("%d", page)); err != nil {
+				return fmt.Errorf("incremental: save page cursor: %w", err)
+			}
+
+			slog.Info("incremental: enqueued changes page",
+				"start_date", startDate, "end_date", endDate,
+				"page", page, "total_pages", resp.TotalPages, "movie_count", len(ids))
+
+			if page >= resp.TotalPages {
+				break
+			}
+		}
+
+		if err := js.SetState(stateWatermark, endDate); err != nil {
+			return fmt.Errorf("incremental: save watermark: %w", err)
+		}
+		if err := js.DeleteState(stateRangeStart); err != nil {
+			return fmt.Errorf("incremental: clear range start: %w", err)
+		}
+		if err := js.DeleteState(stateRangeEnd); err != nil {
+			return fmt.Errorf("incremental: clear range end: %w", err)
+		}
+		if err := js.DeleteState(stateRangePage); err != nil {
+			return fmt.Errorf("incremental: clear range page: %w", err)
+		}
+	}
+}
+
+// loadOrStartWindow returns the ≤14-day window and last completed page an
+// incremental run should continue from: a saved in-progress window if one
+// was left behind by a killed run, otherwise the next window starting at
+// the last watermark (or changesLookback ago, on the very first run).
+// caughtUp is true once the watermark has reached today, meaning there's
+// no further window to process.
+func loadOrStartWindow(js *jobstore.JobStore) (startDate, endDate string, page int, caughtUp bool, err error) {
+	start, startOK, err := js.GetState(stateRangeStart)
+	if err != nil {
+		return "", "", 0, false, err
+	}
+	end, endOK, err := js.GetState(stateRangeEnd)
+	if err != nil {
+		return "", "", 0, false, err
+	}
+
+	if startOK && endOK {
+		pageStr, _, err := js.GetState(stateRangePage)
+		if err != nil {
+			return "", "", 0, false, err
+		}
+		var resumePage int
+		fmt.Sscanf(pageStr, "%d", &resumePage)
+		return start, end, resumePage, false, nil
+	}
+
+	watermark, ok, err := js.GetState(stateWatermark)
+	if err != nil {
+		return "", "", 0, false, err
+	}
+
+	since := time.Now().Add(-changesLookback)
+	if ok {
+		if parsed, err := time.Parse(tmdbDateLayout, watermark); err == nil {
+			since = parsed
+		}
+	}
+
+	// Compared and capped as dates, not instants: TMDB's changes endpoint
+	// is day-granular, and since/today otherwise carry a time-of-day that
+	// would make since always look "before" today even once the
+	// watermark has reached today's date, looping on a zero-progress
+	// same-day window forever.
+	todayDate := time.Now().Format(tmdbDateLayout)
+	sinceDate := since.Format(tmdbDateLayout)
+	if sinceDate >= todayDate {
+		return "", "", 0, true, nil
+	}
+
+	windowEndDate := since.Add(changesLookback).Format(tmdbDateLayout)
+	if windowEndDate > todayDate {
+		windowEndDate = todayDate
+	}
+
+	start = sinceDate
+	end = windowEndDate
+
+	if err := js.SetState(stateRangeStart, start); err != nil {
+		return "", "", 0, false, err
+	}
+	if err := js.SetState(stateRangeEnd, end); err != nil {
+		return "", "", 0, false, err
+	}
+
+	return start, end, 0, false, nil
+}
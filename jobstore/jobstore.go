@@ -0,0 +1,248 @@
+// Package jobstore provides a durable, SQLite-backed queue for movie IDs
+// awaiting TMDB processing. It replaces a simple buffered channel with a
+// table of job rows so that a crashed or killed crawl resumes where it left
+// off instead of re-downloading everything on the next run.
+package jobstore
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// Status is the lifecycle state of a single job row.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusInFlight Status = "in_flight"
+	StatusDone     Status = "done"
+	StatusFailed   Status = "failed"
+)
+
+// leaseDuration bounds how long a worker may hold a claimed job before it is
+// considered abandoned (process killed, worker hung) and eligible to be
+// reclaimed by another worker.
+const leaseDuration = 2 * time.Minute
+
+// Job is a single movie ID's processing record.
+type Job struct {
+	MovieID     int
+	Status      Status
+	Attempts    int
+	LastError   string
+	LeaseExpiry time.Time
+}
+
+// JobStore is a durable, resumable job queue backed by a SQLite database.
+type JobStore struct {
+	db *sql.DB
+}
+
+// New opens (creating if necessary) the job store at path and reclaims the
+// jobs left in_flight by a previous run whose lease has already expired;
+// ClaimNextJob picks up the rest once their leases run out.
+func New(path string) (*JobStore, error) {
+	// WAL lets readers and writers proceed concurrently instead of
+	// serializing on a single file lock, and busy_timeout makes a writer
+	// that does lose the race wait and retry internally instead of
+	// returning SQLITE_BUSY immediately — both matter here since up to
+	// numWorkers goroutines hit this file concurrently via ClaimNextJob,
+	// MarkDone, and MarkFailed.
+	dsn := fmt.Sprintf("%s?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("jobstore: open %s: %w", path, err)
+	}
+
+	const schema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	movie_id     INTEGER PRIMARY KEY,
+	status       TEXT NOT NULL DEFAULT 'pending',
+	attempts     INTEGER NOT NULL DEFAULT 0,
+	last_error   TEXT NOT NULL DEFAULT '',
+	lease_expiry TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status);
+CREATE TABLE IF NOT EXISTS crawl_state (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+`
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("jobstore: create schema: %w", err)
+	}
+
+	js := &JobStore{db: db}
+	if err := js.reclaimStaleJobs(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return js, nil
+}
+
+// Close releases the underlying database handle.
+func (js *JobStore) Close() error {
+	return js.db.Close()
+}
+
+// reclaimStaleJobs resets jobs left in_flight with an expired lease back to
+// pending, so a process that was killed mid-run doesn't strand its claims.
+func (js *JobStore) reclaimStaleJobs() error {
+	_, err := js.db.Exec(
+		`UPDATE jobs SET status = ? WHERE status = ? AND lease_expiry < ?`,
+		StatusPending, StatusInFlight, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("jobstore: reclaim stale jobs: %w", err)
+	}
+	return nil
+}
+
+// EnqueueMovieIDs inserts the given movie IDs as pending jobs. IDs already
+// present are left untouched, so re-running against the same ID file is safe.
+func (js *JobStore) EnqueueMovieIDs(movieIDs []int) error {
+	tx, err := js.db.Begin()
+	if err != nil {
+		return fmt.Errorf("jobstore: begin enqueue: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO jobs (movie_id) VALUES (?)`)
+	if err != nil {
+		return fmt.Errorf("jobstore: prepare enqueue: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, id := range movieIDs {
+		if _, err := stmt.Exec(id); err != nil {
+			return fmt.Errorf("jobstore: enqueue movie %d: %w", id, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ClaimNextJob atomically claims the claimable job with the lowest movie ID
+// for workerID (a pending job whose retry backoff has elapsed, or an
+// in_flight job whose lease has expired), marking it in_flight with a fresh
+// lease. It returns nil, nil when no work is currently available.
+func (js *JobStore) ClaimNextJob(workerID string) (*Job, error) {
+	tx, err := js.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("jobstore: begin claim: %w", err)
+	}
+	defer tx.Rollback()
+
+	var job Job
+	row := tx.QueryRow(
+		`SELECT movie_id, attempts, last_error FROM jobs
+		 WHERE (status = ? AND (lease_expiry IS NULL OR lease_expiry <= ?))
+		    OR (status = ? AND lease_expiry <= ?)
+		 ORDER BY movie_id LIMIT 1`,
+		StatusPending, time.Now(), StatusInFlight, time.Now(),
+	)
+	if err := row.Scan(&job.MovieID, &job.Attempts, &job.LastError); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("jobstore: claim for %s: %w", workerID, err)
+	}
+
+	job.Status = StatusInFlight
+	job.LeaseExpiry = time.Now().Add(leaseDuration)
+
+	_, err = tx.Exec(
+		`UPDATE jobs SET status = ?, lease_expiry = ? WHERE movie_id = ?`,
+		job.Status, job.LeaseExpiry, job.MovieID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("jobstore: lease job %d for %s: %w", job.MovieID, workerID, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("jobstore: commit claim: %w", err)
+	}
+
+	return &job, nil
+}
+
+// MarkDone marks movieID's job as successfully completed.
+func (js *JobStore) MarkDone(movieID int) error {
+	_, err := js.db.Exec(`UPDATE jobs SET status = ?, last_error = '' WHERE movie_id = ?`, StatusDone, movieID)
+	if err != nil {
+		return fmt.Errorf("jobstore: mark %d done: %w", movieID, err)
+	}
+	return nil
+}
+
+// MarkFailed records jobErr against movieID's job, bumps its attempt count,
+// and reschedules it as pending after backoff (or leaves it failed if the
+// caller passes a zero backoff to signal no further retries).
+func (js *JobStore) MarkFailed(movieID int, jobErr error, backoff time.Duration) error {
+	status := StatusPending
+	leaseExpiry := time.Now().Add(backoff)
+	if backoff <= 0 {
+		status = StatusFailed
+	}
+
+	_, err := js.db.Exec(
+		`UPDATE jobs SET status = ?, attempts = attempts + 1, last_error = ?, lease_expiry = ? WHERE movie_id = ?`,
+		status, jobErr.Error(), leaseExpiry, movieID,
+	)
+	if err != nil {
+		return fmt.Errorf("jobstore: mark %d failed: %w", movieID, err)
+	}
+	return nil
+}
+
+// PendingCount returns the number of jobs still pending or in_flight, for
+// callers that need to know when a run has fully drained.
+func (js *JobStore) PendingCount() (int, error) {
+	var count int
+	row := js.db.QueryRow(`SELECT COUNT(*) FROM jobs WHERE status IN (?, ?)`, StatusPending, StatusInFlight)
+	if err := row.Scan(&count); err != nil {
+		return 0, fmt.Errorf("jobstore: pending count: %w", err)
+	}
+	return count, nil
+}
+
+// GetState returns the value stored under key (e.g. an incremental-crawl
+// watermark), and false if it has never been set.
+func (js *JobStore) GetState(key string) (string, bool, error) {
+	var value string
+	row := js.db.QueryRow(`SELECT value FROM crawl_state WHERE key = ?`, key)
+	if err := row.Scan(&value); err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("jobstore: get state %q: %w", key, err)
+	}
+	return value, true, nil
+}
+
+// SetState persists value under key, overwriting any previous value.
+func (js *JobStore) SetState(key, value string) error {
+	_, err := js.db.Exec(
+		`INSERT INTO crawl_state (key, value) VALUES (?, ?)
+		 ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+		key, value,
+	)
+	if err != nil {
+		return fmt.Errorf("jobstore: set state %q: %w", key, err)
+	}
+	return nil
+}
+
+// DeleteState removes key, if present. Used to clear an in-progress cursor
+// once a multi-page crawl completes.
+func (js *JobStore) DeleteState(key string) error {
+	if _, err := js.db.Exec(`DELETE FROM crawl_state WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("jobstore: delete state %q: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+)
+
+// traceIDKey is the context key a per-movie trace ID is stored under, so
+// every log line touching a given TMDB ID can be correlated.
+type traceIDKey struct{}
+
+// withTraceID returns a child context carrying traceID.
+func withTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// traceIDFromContext returns the trace ID stored in ctx, or "" if none.
+func traceIDFromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+// loggerFor returns the default slog.Logger annotated with ctx's trace ID,
+// if any.
+func loggerFor(ctx context.Context) *slog.Logger {
+	l := slog.Default()
+	if traceID := traceIDFromContext(ctx); traceID != "" {
+		l = l.With("trace_id", traceID)
+	}
+	return l
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pogorskii/tmdb-movies-data/jobstore"
+)
+
+// Crawl modes selectable via --mode, controlling how movie IDs to crawl are
+// discovered before being handed to the worker pool.
+const (
+	modeBulk        = "bulk"
+	modeIncremental = "incremental"
+	modeDailyExport = "daily-export"
+)
+
+// discoverAndEnqueue populates js with the movie IDs to crawl for the
+// given mode: modeBulk reads the historical movie_ids.json file,
+// modeIncremental walks TMDB's changes endpoint since the last watermark,
+// and modeDailyExport diffs TMDB's daily export against IDs js already
+// knows about.
+func discoverAndEnqueue(ctx context.Context, mode string, js *jobstore.JobStore) error {
+	switch mode {
+	case modeBulk:
+		movieIDs, err := readMovieIDsFromFile("movie_ids.json")
+		if err != nil {
+			return fmt.Errorf("reading movie IDs file: %w", err)
+		}
+		ids := make([]int, len(movieIDs))
+		for i, movieID := range movieIDs {
+			ids[i] = movieID.ID
+		}
+		return js.EnqueueMovieIDs(ids)
+	case modeIncremental:
+		return runIncrementalMode(ctx, js)
+	case modeDailyExport:
+		return runDailyExportMode(ctx, js)
+	default:
+		return fmt.Errorf("unknown --mode %q (expected %s, %s, or %s)", mode, modeBulk, modeIncremental, modeDailyExport)
+	}
+}
@@ -0,0 +1,98 @@
+// Package moviedata holds the domain types shared between the crawler and
+// the storage sinks: the shape of a processed TMDB movie record.
+package moviedata
+
+type Movie struct {
+	ID                  int        `json:"id"`
+	OriginalLanguage    string     `json:"original_language"`
+	OriginalTitle       string     `json:"original_title"`
+	Title               string     `json:"title"`
+	PosterPath          *string    `json:"poster_path"`
+	Popularity          float64    `json:"popularity"`
+	Runtime             int        `json:"runtime"`
+	Budget              int        `json:"budget"`
+	ReleaseDate         string     `json:"release_date"`
+	Releases            []Release  `json:"release_dates"`
+	Genres              []int      `json:"genres"`
+	ProductionCountries []Country  `json:"production_countries"`
+	Actors              []Actor    `json:"actors"`
+	Directors           []Director `json:"directors"`
+
+	// The fields below are only populated when the corresponding
+	// --include sub-resource was requested; otherwise they're left at
+	// their zero value and omitted from JSON output.
+	Images            *Images            `json:"images,omitempty"`
+	Videos            []Video            `json:"videos,omitempty"`
+	Keywords          []string           `json:"keywords,omitempty"`
+	ExternalIDs       *ExternalIDs       `json:"external_ids,omitempty"`
+	AlternativeTitles []AlternativeTitle `json:"alternative_titles,omitempty"`
+}
+
+type Country struct {
+	ISO3166_1 string `json:"iso_3166_1"`
+	Name      string `json:"name"`
+}
+
+type Release struct {
+	ISO639_1          string             `json:"iso_639_1"`
+	LocalReleaseDates []LocalReleaseDate `json:"local_release_dates"`
+}
+
+type LocalReleaseDate struct {
+	Note        string `json:"note"`
+	ReleaseDate string `json:"release_date"`
+	Type        int    `json:"type"`
+}
+
+type Actor struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Order int    `json:"order"`
+}
+
+type Director struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type MovieID struct {
+	ID int `json:"id"`
+}
+
+// Image is a single poster or backdrop entry from the images sub-resource.
+type Image struct {
+	FilePath string  `json:"file_path"`
+	Width    int     `json:"width"`
+	Height   int     `json:"height"`
+	ISO639_1 *string `json:"iso_639_1"`
+}
+
+// Images holds the images sub-resource, split by placement.
+type Images struct {
+	Backdrops []Image `json:"backdrops"`
+	Posters   []Image `json:"posters"`
+}
+
+// Video is a single trailer/clip entry from the videos sub-resource.
+type Video struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Site string `json:"site"`
+	Type string `json:"type"`
+}
+
+// ExternalIDs holds cross-references to the movie on other platforms.
+type ExternalIDs struct {
+	IMDbID      string `json:"imdb_id"`
+	WikidataID  string `json:"wikidata_id"`
+	FacebookID  string `json:"facebook_id"`
+	InstagramID string `json:"instagram_id"`
+	TwitterID   string `json:"twitter_id"`
+}
+
+// AlternativeTitle is a localized title from the alternative_titles
+// sub-resource.
+type AlternativeTitle struct {
+	ISO3166_1 string `json:"iso_3166_1"`
+	Title     string `json:"title"`
+}
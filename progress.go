@@ -0,0 +1,61 @@
+package main
+
+import (
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/pogorskii/tmdb-movies-data/jobstore"
+)
+
+// successCount and failCount are bumped by fetchAndProcessMovieData and
+// read by reportProgress; atomic so neither side needs a mutex.
+var (
+	successCount atomic.Int64
+	failCount    atomic.Int64
+)
+
+// reportProgress logs throughput, success/fail counts, the limiter's
+// current rate, queue depth, and an ETA every interval, until stop is
+// closed. Run it in its own goroutine.
+func reportProgress(js *jobstore.JobStore, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	started := time.Now()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			succeeded := successCount.Load()
+			failed := failCount.Load()
+			total := succeeded + failed
+
+			elapsed := time.Since(started).Seconds()
+			throughput := float64(total) / elapsed
+
+			pending, err := js.PendingCount()
+			if err != nil {
+				slog.Error("progress: checking pending count", "error", err)
+				continue
+			}
+
+			var eta time.Duration
+			if throughput > 0 {
+				eta = time.Duration(float64(pending)/throughput) * time.Second
+			}
+
+			slog.Info("progress",
+				"processed_total", total,
+				"succeeded", succeeded,
+				"failed", failed,
+				"movies_per_sec", throughput,
+				"rate_limit_per_sec", float64(limiter.currentRate()),
+				"queue_depth", pending,
+				"eta", eta.String(),
+			)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// maxRequestRate is a ceiling on the adaptive rate, matching TMDB's
+// published limit, so a generous X-RateLimit-Remaining window can't push
+// us past what the API actually allows.
+const maxRequestRate rate.Limit = 50
+
+// adaptiveLimiter wraps a rate.Limiter whose rate is retuned on every TMDB
+// response: it backs off hard on a 429's Retry-After, and otherwise
+// tracks X-RateLimit-Remaining/X-RateLimit-Reset so the crawler runs near
+// TMDB's real ceiling without manual tuning.
+type adaptiveLimiter struct {
+	mu      sync.Mutex
+	limiter *rate.Limiter
+}
+
+func newAdaptiveLimiter() *adaptiveLimiter {
+	return &adaptiveLimiter{limiter: rate.NewLimiter(maxRequestRate, 1)}
+}
+
+func (a *adaptiveLimiter) Wait(ctx context.Context) error {
+	a.mu.Lock()
+	l := a.limiter
+	a.mu.Unlock()
+	return l.Wait(ctx)
+}
+
+// updateFromResponse retunes the limiter's rate based on res's headers.
+// A 429's Retry-After takes priority over the ambient rate-limit headers.
+func (a *adaptiveLimiter) updateFromResponse(res *http.Response) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if res.StatusCode == http.StatusTooManyRequests {
+		if retryAfter := res.Header.Get("Retry-After"); retryAfter != "" {
+			if secs, err := strconv.Atoi(retryAfter); err == nil && secs > 0 {
+				a.limiter.SetLimit(rate.Every(time.Duration(secs) * time.Second))
+				return
+			}
+		}
+	}
+
+	remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+	if err != nil || remaining <= 0 {
+		return
+	}
+
+	resetUnix, err := strconv.ParseFloat(res.Header.Get("X-RateLimit-Reset"), 64)
+	if err != nil {
+		return
+	}
+
+	window := time.Until(time.Unix(int64(resetUnix), 0))
+	if window <= 0 {
+		return
+	}
+
+	newLimit := rate.Limit(float64(remaining) / window.Seconds())
+	if newLimit > maxRequestRate {
+		newLimit = maxRequestRate
+	}
+	a.limiter.SetLimit(newLimit)
+}
+
+// currentRate returns the limiter's current requests-per-second rate, for
+// reporting; the underlying library doesn't expose a live token count.
+func (a *adaptiveLimiter) currentRate() rate.Limit {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.limiter.Limit()
+}
@@ -3,429 +3,292 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
+	"log/slog"
+	"math/rand"
 	"net/http"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
-	"golang.org/x/time/rate"
-)
-
-type Movie struct {
-	ID 									int						`json:"id"`
-	OriginalLanguage    string        `json:"original_language"`
-  OriginalTitle     	string        `json:"original_title"`
-	Title     					string        `json:"title"`
-	PosterPath          *string       `json:"poster_path"`
-	Popularity         	float64				`json:"popularity"`
-	Runtime             int           `json:"runtime"`
-  Budget              int           `json:"budget"`
-	ReleaseDate         string        `json:"release_date"`
-	Releases        		[]Release  		`json:"release_dates"`
-	Genres              []int         `json:"genres"`
-	ProductionCountries []Country			`json:"production_countries"`
-	Actors 							[]Actor				  `json:"actors"`
-	Directors						[]Director		      `json:"directors"`
-}
-
-type Country struct {
-  ISO3166_1 string `json:"iso_3166_1"`
-  Name      string `json:"name"`
-}
-
-type Release struct {
-  ISO639_1    string   `json:"iso_639_1"`
-	LocalReleaseDates []LocalReleaseDate `json:"local_release_dates"`
-}
-
-type LocalReleaseDate struct {
-	Note        string   `json:"note"`
-  ReleaseDate string   `json:"release_date"`
-  Type        int      `json:"type"`
-}
 
-type Actor struct {
-	ID						int				`json:"id"`
-	Name 					string 		`json:"name"`
-	Order					int				`json:"order"`
-}
-
-type Director struct {
-	ID						int				`json:"id"`
-	Name 					string 		`json:"name"`
-}
-
-type MovieID struct {
-  ID int `json:"id"`
-}
+	"github.com/pogorskii/tmdb-movies-data/jobstore"
+	"github.com/pogorskii/tmdb-movies-data/moviedata"
+	"github.com/pogorskii/tmdb-movies-data/sink"
+)
 
 var (
-  // Rate limiter with 50 requests per second and 1 burst token
-  limiter = rate.NewLimiter(rate.Every(time.Second/50), 1)
+  // Adaptive rate limiter, retuned on every TMDB response
+  limiter = newAdaptiveLimiter()
 
   // Mutex to protect access to processed movie data
   processedMovieDataMutex sync.Mutex
-  processedMovieData map[int]*Movie
+  processedMovieData map[int]*moviedata.Movie
 
-  // Channels to handle movie IDs and processed data
-  movieIDChannel chan int
-  processedMovieDataChannel chan Movie
+  // Channel to hand processed data back to the write loop
+  processedMovieDataChannel chan moviedata.Movie
 
   // Number of worker goroutines
   numWorkers int
 )
 
-func fetchMovieData(movieID int) ([]byte, error) {
-  // Build the API URL
-  url := fmt.Sprintf("https://api.themoviedb.org/3/movie/%d?append_to_response=release_dates%%2Ccredits&language=en-US", movieID)
-
-  // Create the HTTP request
-  req, err := http.NewRequest("GET", url, nil)
-  if err != nil {
-    return nil, err
-  }
-
-  // Set headers and authorization
-  apiKey := os.Getenv("API_ACCESS_TOKEN")
-  authorizationValue := fmt.Sprintf("Bearer %s", apiKey)
-  req.Header.Add("accept", "application/json")
-  req.Header.Add("Authorization", authorizationValue)
-
-  // Make the HTTP request and handle the response
-  client := http.DefaultClient
-  res, err := client.Do(req)
-  if err != nil {
-    return nil, err
+// claimBackoff is how long a failed job sits before it's eligible to be
+// claimed again.
+const claimBackoff = 30 * time.Second
+
+// maxJobAttempts caps how many times a job may be claimed and fail before
+// it's given up on permanently (StatusFailed) instead of being requeued.
+// Without this, a permanently-bad movie ID (404, deleted, region-locked)
+// would cycle pending -> in_flight -> pending forever and PendingCount
+// would never reach zero, so the crawl would never terminate.
+const maxJobAttempts = 5
+
+// claimBackoffFor returns the backoff to pass to MarkFailed for a job that
+// has already failed attemptsSoFar times: claimBackoff while retries
+// remain, or zero once maxJobAttempts is reached so MarkFailed marks the
+// job permanently failed instead of requeuing it.
+func claimBackoffFor(attemptsSoFar int) time.Duration {
+  if attemptsSoFar+1 >= maxJobAttempts {
+    return 0
   }
-  defer res.Body.Close()
-
-  // Check for successful response
-  if res.StatusCode != http.StatusOK {
-    return nil, fmt.Errorf("unexpected HTTP status code: %d", res.StatusCode)
-  }
-
-  // Read the response body
-  body, err := io.ReadAll(res.Body)
-  if err != nil {
-    return nil, err
-  }
-
-  return body, nil
+  return claimBackoff
 }
 
-func processMovieData(rawData map[string]interface{}) (*Movie, error) {
-  movie := &Movie{}
-
-  // Extract and map basic movie information
-  for key, value := range rawData {
-    switch key {
-    case "id":
-      movie.ID = int(rawData["id"].(float64))
-    case "original_language":
-      movie.OriginalLanguage = value.(string)
-    case "original_title":
-      movie.OriginalTitle = value.(string)
-		case "title":
-      movie.Title = value.(string)
-		case "poster_path":
-			if value == nil {
-				movie.PosterPath = nil // Set to an empty string if nil
-			} else {
-				movie.PosterPath = new(string) // Allocate memory for string
-      	*movie.PosterPath = value.(string) // Store the actual path
-			}
-		case "popularity":
-			movie.Popularity = value.(float64)
-		case "runtime":
-      movie.Runtime = int(rawData["runtime"].(float64))
-    case "budget":
-      movie.Budget = int(rawData["budget"].(float64))
-		case "release_date":
-			movie.ReleaseDate = rawData["release_date"].(string)
-		}
-  }
+// idlePollInterval is how long a worker sleeps when the queue has no
+// claimable job but jobs are still pending (in flight elsewhere, or
+// waiting out a retry backoff).
+const idlePollInterval = 1 * time.Second
 
-  // Extract and parse nested "release_dates" object
-  releaseDates, ok := rawData["release_dates"]
-  if ok {
-    releaseDatesMap, ok := releaseDates.(map[string]interface{})
-    if ok {
-      movie.Releases = parseReleaseDates(releaseDatesMap)
-    }
-  }
+// retry tuning for transient TMDB failures (429s, 5xx bursts)
+const (
+  maxFetchAttempts = 5
+  retryBaseDelay   = 500 * time.Millisecond
+  retryMaxDelay    = 30 * time.Second
+)
 
-  genres, ok := rawData["genres"]
-  if ok {
-    genresMap, ok := genres.([]interface{})
-    if ok {
-      movie.Genres = parseGenres(genresMap)
-    }
-  }
+// fetchMovieData fetches a single movie from TMDB, retrying transient
+// failures (429s, 5xx) with exponential backoff and jitter instead of
+// dropping the movie ID. Every response, success or failure, retunes
+// limiter so the crawler tracks TMDB's real rate-limit window.
+func fetchMovieData(ctx context.Context, movieID int) ([]byte, error) {
+  url := fmt.Sprintf("https://api.themoviedb.org/3/movie/%d?append_to_response=%s&language=en-US", movieID, appendToResponseParam())
+  apiKey := os.Getenv("API_ACCESS_TOKEN")
+  authorizationValue := fmt.Sprintf("Bearer %s", apiKey)
 
-  productionCountries, ok := rawData["production_countries"]
-  if ok {
-    countriesMap, ok := productionCountries.([]interface{})
-    if ok {
-      movie.ProductionCountries = parseProductionCountries(countriesMap)
+  var lastErr error
+  for attempt := 0; attempt < maxFetchAttempts; attempt++ {
+    if err := limiter.Wait(ctx); err != nil {
+      return nil, err
     }
-  }
 
-	// Extract and parse nested "credits" object
-	credits, ok := rawData["credits"]
-	if ok {
-		credits, ok := credits.(map[string]interface{})
-		if ok {
-			movie.Actors = parseActors(credits)
-			movie.Directors = parseDirectors(credits)
-		}
-	}
-
-  return movie, nil
-}
-
-func parseReleaseDates(releaseDatesMap map[string]interface{}) []Release {
-  var releaseDates []Release
-
-  // Pre-allocate memory for the slice
-  releaseDates = make([]Release, 0, len(releaseDatesMap["results"].([]interface{})))
-
-  // Loop through each language release data
-  for _, languageData := range releaseDatesMap["results"].([]interface{}) {
-    languageMap, ok := languageData.(map[string]interface{})
-    if !ok {
-      continue
+    req, err := http.NewRequest("GET", url, nil)
+    if err != nil {
+      return nil, err
     }
+    req.Header.Add("accept", "application/json")
+    req.Header.Add("Authorization", authorizationValue)
 
-    // Extract ISO code and local release dates
-    isoCode := languageMap["iso_3166_1"].(string)
-    localReleaseDatesMap, ok := languageMap["release_dates"].([]interface{})
-    if !ok {
+    res, err := http.DefaultClient.Do(req)
+    if err != nil {
+      lastErr = err
+      time.Sleep(retryDelay(attempt))
       continue
     }
 
-    // Parse local release date information
-    var localReleaseDates []LocalReleaseDate
-    for _, releaseDateMap := range localReleaseDatesMap {
-      localReleaseDate, err := parseLocalReleaseDate(releaseDateMap.(map[string]interface{}))
-      if err != nil {
-        continue
-      }
-      localReleaseDates = append(localReleaseDates, *localReleaseDate)
-    }
-
-    releaseDates = append(releaseDates, Release{
-      ISO639_1: isoCode,
-      LocalReleaseDates: localReleaseDates,
-    })
-  }
-
-  return releaseDates
-}
+    limiter.updateFromResponse(res)
 
-func parseGenres(genresMap []interface{}) []int {
-  var genres []int
-
-  genres = make([]int, 0, len(genresMap))
-
-  for _, genreData := range genresMap {
-    genreMap, ok := genreData.(map[string]interface{})
-    if !ok {
-      continue
+    if res.StatusCode == http.StatusOK {
+      body, err := io.ReadAll(res.Body)
+      res.Body.Close()
+      return body, err
     }
 
-    genres = append(genres, int(genreMap["id"].(float64)))
-  }
+    res.Body.Close()
 
-  return genres
-}
-
-func parseProductionCountries(countriesMap []interface{}) []Country {
-  var countries []Country
-
-  countries = make([]Country, 0, len(countriesMap))
-
-  for _, countryData := range countriesMap {
-    countryMap, ok := countryData.(map[string]interface{})
-    if !ok {
+    if res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500 {
+      lastErr = fmt.Errorf("unexpected HTTP status code: %d", res.StatusCode)
+      time.Sleep(retryDelay(attempt))
       continue
     }
 
-    ISO3166_1 := countryMap["iso_3166_1"].(string)
-    name := countryMap["name"].(string)
-
-    countries = append(countries, Country{
-      ISO3166_1: ISO3166_1,
-      Name: name,
-    })
+    return nil, fmt.Errorf("unexpected HTTP status code: %d", res.StatusCode)
   }
 
-  return countries
+  return nil, fmt.Errorf("movie %d: giving up after %d attempts: %w", movieID, maxFetchAttempts, lastErr)
 }
 
-func parseActors(actorsMap map[string]interface{}) []Actor {
-	var actors []Actor
-
-	for _, actorData := range actorsMap["cast"].([]interface{}) {
-		actorMap, ok := actorData.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-    order := int(actorMap["order"].(float64))
-
-    if order < 5 {
-      // Update the actor slice capacity to accommodate a new element
-      actors = append(actors, Actor{}) // Append a zero-initialized element
-      lastActorIndex := len(actors) - 1
-
-      // Populate the newly added element
-      actors[lastActorIndex].ID = int(actorMap["id"].(float64))
-      actors[lastActorIndex].Name = actorMap["name"].(string)
-      actors[lastActorIndex].Order = order
-    }
-	}
-
-	return actors
-}
-
-
-func parseDirectors(directorsMap map[string]interface{}) []Director {
-	var directors []Director
-
-  // Pre-allocate memory for the slice
-  directors = make([]Director, 0, len(directorsMap["crew"].([]interface{})))
-
-	for _, directorData := range directorsMap["crew"].([]interface{}) {
-		directorMap, ok := directorData.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-    if directorMap["job"] == "Director" {
-      id := int(directorMap["id"].(float64))
-		  name := directorMap["name"].(string)
-
-      directors = append(directors, Director{
-        ID: id,
-        Name: name,
-      })
-    }
-	}
-
-	return directors
-}
-
-
-func parseLocalReleaseDate(releaseDateMap map[string]interface{}) (*LocalReleaseDate, error) {
-  localReleaseDate := &LocalReleaseDate{}
-
-  // Assign extracted values
-  localReleaseDate.Note = releaseDateMap["note"].(string)
-  localReleaseDate.ReleaseDate = releaseDateMap["release_date"].(string)
-  localReleaseDate.Type = int(releaseDateMap["type"].(float64))
-
-  return localReleaseDate, nil
+// retryDelay returns an exponential backoff with full jitter for the given
+// (zero-indexed) attempt, capped at retryMaxDelay.
+func retryDelay(attempt int) time.Duration {
+  exp := retryBaseDelay << attempt
+  if exp <= 0 || exp > retryMaxDelay {
+    exp = retryMaxDelay
+  }
+  return time.Duration(rand.Int63n(int64(exp)))
 }
 
-func fetchAndProcessMovieData(movieIDChannel chan int, processedMovieDataChannel chan Movie) {
-  for movieID := range movieIDChannel {
-    // Apply rate limiting
-    if err := limiter.Wait(context.Background()); err != nil {
-      fmt.Printf("Rate limit exceeded for movie ID %d: %v\n", movieID, err)
+// fetchAndProcessMovieData drains js of claimable jobs, processing one movie
+// ID at a time, until the queue is fully drained (nothing pending or
+// in_flight anywhere). It returns once there is no more work so the caller
+// can wait on a sync.WaitGroup.
+func fetchAndProcessMovieData(workerID string, js *jobstore.JobStore, processedMovieDataChannel chan moviedata.Movie) {
+  for {
+    job, err := js.ClaimNextJob(workerID)
+    if err != nil {
+      slog.Error("error claiming job", "worker_id", workerID, "error", err)
+      time.Sleep(idlePollInterval)
       continue
     }
 
-    // Fetch and process movie data
-    body, err := fetchMovieData(movieID)
-    if err != nil {
-      fmt.Printf("Error fetching movie data for ID %d: %v\n", movieID, err)
+    if job == nil {
+      pending, err := js.PendingCount()
+      if err != nil {
+        slog.Error("error checking pending count", "worker_id", workerID, "error", err)
+      }
+      if pending == 0 {
+        return
+      }
+      time.Sleep(idlePollInterval)
       continue
     }
 
-    var rawData map[string]interface{}
-    err = json.Unmarshal(body, &rawData)
+    movieID := job.MovieID
+    ctx := withTraceID(context.Background(), fmt.Sprintf("movie-%d", movieID))
+    log := loggerFor(ctx).With("worker_id", workerID, "movie_id", movieID)
+
+    // Fetch and process movie data (fetchMovieData applies rate limiting
+    // and retries transient failures itself)
+    body, err := fetchMovieData(ctx, movieID)
     if err != nil {
-      fmt.Println(err)
+      log.Error("error fetching movie data", "error", err)
+      js.MarkFailed(movieID, err, claimBackoffFor(job.Attempts))
+      failCount.Add(1)
       continue
     }
 
-    processedData, err := processMovieData(rawData)
-    if err != nil {
-      fmt.Println(err)
+    var resp tmdbMovieResponse
+    if err := json.Unmarshal(body, &resp); err != nil {
+      log.Error("error decoding movie data", "error", err)
+      js.MarkFailed(movieID, err, claimBackoffFor(job.Attempts))
+      failCount.Add(1)
       continue
     }
 
-    // Send processed data back to the main goroutine
-    processedMovieDataChannel <- *processedData
+    processedData := resp.toMovie()
+    successCount.Add(1)
+
+    // Handed to the write loop, which marks the job done only once the
+    // sink has durably accepted the batch containing it (see main) —
+    // marking it done here, before the data is flushed anywhere, would
+    // lose it for good on a crash or a failed batch write.
+    processedMovieDataChannel <- processedData
+  }
+}
+
+// markBatchDone marks every movie in a just-written batch as done in js.
+// Called only after dataSink.WriteBatch has returned successfully, so a
+// job is never marked done until its data has actually landed in the
+// sink.
+func markBatchDone(js *jobstore.JobStore, batch []moviedata.Movie) {
+  for _, movie := range batch {
+    if err := js.MarkDone(movie.ID); err != nil {
+      slog.Error("error marking movie done", "movie_id", movie.ID, "error", err)
+    }
   }
 }
 
 var writeTimeout = 410 * time.Second // Define a timeout for writing
 
+// progressInterval is how often reportProgress logs a throughput summary.
+const progressInterval = 10 * time.Second
+
 func main() {
+  slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+
   // Load the .env file automatically
   err := godotenv.Load()
   if err != nil {
-    fmt.Println("Error loading .env file:", err)
+    slog.Error("error loading .env file", "error", err)
     return
   }
 
-  timestamp := time.Now().Format("15:04:05")
-  fmt.Printf("Started executing at %s \n", timestamp)
+  includeFlag := flag.String("include", "", "comma-separated TMDB sub-resources to additionally fetch: images,videos,keywords,external_ids,alternative_titles")
+  modeFlag := flag.String("mode", modeBulk, fmt.Sprintf("how to discover movie IDs to crawl: %s (read movie_ids.json), %s (TMDB changes endpoint since last watermark), or %s (TMDB daily export diffed against known IDs)", modeBulk, modeIncremental, modeDailyExport))
+  flag.Parse()
+  enabledIncludes = parseIncludes(*includeFlag)
+
+  slog.Info("started executing")
 
   // Set the number of worker goroutines
   numWorkers = 500
 
-  // Initialize channels with appropriate buffer size
-  movieIDChannel = make(chan int, 10000000)
-  processedMovieDataChannel = make(chan Movie, 1000000)
+  // Open the durable job queue. Any jobs left in_flight by a killed
+  // previous run are reclaimed as pending automatically.
+  jobStorePath := os.Getenv("JOBSTORE_PATH")
+  if jobStorePath == "" {
+    jobStorePath = "jobs.db"
+  }
+  js, err := jobstore.New(jobStorePath)
+  if err != nil {
+    slog.Error("error opening job store", "error", err)
+    return
+  }
+  defer js.Close()
+
+  // Select the sink backend (SINK=json|sqlite|postgres, defaulting to
+  // json to match the tool's historical behavior).
+  dataSink, err := sink.FromEnv()
+  if err != nil {
+    slog.Error("error opening sink", "error", err)
+    return
+  }
+  defer dataSink.Close()
+
+  // Discover movie IDs to crawl and enqueue them. IDs already present
+  // from a prior run (done, failed, or still pending) are left untouched.
+  if err := discoverAndEnqueue(context.Background(), *modeFlag, js); err != nil {
+    slog.Error("error discovering movie IDs", "mode", *modeFlag, "error", err)
+    return
+  }
+
+  // Initialize channel with appropriate buffer size
+  processedMovieDataChannel = make(chan moviedata.Movie, 1000000)
 
   // Create a separate channel to signal worker completion
   workerDone := make(chan struct{})
   // Create a single channel to signal both worker completion and write completion
   done := make(chan struct{})
 
-  // Spawn worker goroutines for fetching and processing data
+  // Spawn worker goroutines for fetching and processing data, each
+  // pulling its own work from the job store until it's drained.
   var wg sync.WaitGroup
   wg.Add(numWorkers)
 
-  // Spawn worker goroutines for fetching and processing data
   for i := 0; i < numWorkers; i++ {
+    workerID := fmt.Sprintf("worker-%d", i)
     go func() {
-      fetchAndProcessMovieData(movieIDChannel, processedMovieDataChannel)
+      fetchAndProcessMovieData(workerID, js, processedMovieDataChannel)
       wg.Done() // Signal worker finished processing
     }()
   }
 
-  // Read movie IDs from JSON file
-  movieIDs, err := readMovieIDsFromFile("movie_ids.json")
-  if err != nil {
-    fmt.Println(err)
-    return
-  }
-
-  // Send movie IDs to the channel for processing
-  for _, movieID := range movieIDs {
-    movieIDChannel <- movieID.ID
-  }
-
-  // Close movie ID channel after sending all IDs
-  close(movieIDChannel)
-
   // Launch a separate goroutine to monitor worker completion
   go func() {
     wg.Wait()
     close(workerDone)
   }()
 
-  var processedMovieData []Movie
+  // Launch the progress reporter; it stops once all workers are done.
+  go reportProgress(js, progressInterval, workerDone)
+
+  var processedMovieData []moviedata.Movie
   var processedMovieDataMutex sync.Mutex
 
   const batchSize = 100
-  var batchBuffer []Movie
+  var batchBuffer []moviedata.Movie
 
   var wgWrite sync.WaitGroup
 
@@ -446,35 +309,35 @@ func main() {
 
         if len(batchBuffer) >= batchSize {
           wgWrite.Add(1)
-          timestamp := time.Now().Format("15:04:05")
-          fmt.Printf("Writing data for batch with the size of %d at %s \n", len(batchBuffer), timestamp)
-          err := writeMovieDataToJSONFile(batchBuffer)
+          slog.Info("writing batch", "batch_size", len(batchBuffer))
+          err := dataSink.WriteBatch(batchBuffer)
           if err != nil {
-            fmt.Println(err)
+            slog.Error("error writing batch", "error", err)
             return
           }
+          markBatchDone(js, batchBuffer)
 
           timer.Reset(writeTimeout) // Reset timer on successful write
 
           wgWrite.Done()
 
           // Clear batch buffer
-          batchBuffer = []Movie{}
+          batchBuffer = []moviedata.Movie{}
         }
       case <-timer.C:
         wgWrite.Add(1)
         // Write any remaining data after timeout
         if len(batchBuffer) > 0 {
-          timestamp := time.Now().Format("15:04:05")
-          fmt.Printf("Writing data for batch with the size of %d at %s \n", len(batchBuffer), timestamp)
-          err := writeMovieDataToJSONFile(batchBuffer)
+          slog.Info("writing final batch", "batch_size", len(batchBuffer))
+          err := dataSink.WriteBatch(batchBuffer)
           if err != nil {
-            fmt.Println(err)
+            slog.Error("error writing batch", "error", err)
             return
           }
+          markBatchDone(js, batchBuffer)
 
           // Clear batch buffer
-          batchBuffer = []Movie{}
+          batchBuffer = []moviedata.Movie{}
         }
         // Signal program completion after writing remaining data
         wgWrite.Done()
@@ -489,10 +352,15 @@ func main() {
   wgWrite.Wait() // Wait for all write operations to finish
   close(processedMovieDataChannel) // Close channel after all data is processed
 
-  fmt.Println("Successfully processed and saved movie data")
+  if err := dataSink.Flush(); err != nil {
+    slog.Error("error flushing sink", "error", err)
+    return
+  }
+
+  slog.Info("successfully processed and saved movie data")
 }
 
-func readMovieIDsFromFile(filename string) ([]MovieID, error) {
+func readMovieIDsFromFile(filename string) ([]moviedata.MovieID, error) {
   // Open the file
   file, err := os.Open(filename)
   if err != nil {
@@ -507,7 +375,7 @@ func readMovieIDsFromFile(filename string) ([]MovieID, error) {
   }
 
   // Declare a slice of MovieID objects
-  var movieIDs []MovieID
+  var movieIDs []moviedata.MovieID
 
   // Unmarshal the data into the slice
   err = json.Unmarshal(data, &movieIDs)
@@ -517,27 +385,3 @@ func readMovieIDsFromFile(filename string) ([]MovieID, error) {
 
   return movieIDs, nil
 }
-
-func writeMovieDataToJSONFile(data []Movie) error {
-  // Generate a unique filename based on current timestamp
-  timestamp := time.Now().Format("15-04-05")
-  filename := fmt.Sprintf("processed_movies_%s.json", timestamp)
-
-  // Open the file for writing
-  file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-  if err != nil {
-    return err
-  }
-  defer file.Close()
-
-  // Encode the data to JSON format
-  encoder := json.NewEncoder(file)
-
-  // Write the data to the file
-  err = encoder.Encode(data)
-  if err != nil {
-    return err
-  }
-
-  return nil
-}
\ No newline at end of file
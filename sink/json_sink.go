@@ -0,0 +1,39 @@
+package sink
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pogorskii/tmdb-movies-data/moviedata"
+)
+
+// JSONSink writes each batch to its own timestamped JSON file under dir,
+// preserving the tool's original output format.
+type JSONSink struct {
+	dir string
+}
+
+// NewJSONSink returns a JSONSink that writes batch files into dir.
+func NewJSONSink(dir string) *JSONSink {
+	return &JSONSink{dir: dir}
+}
+
+func (s *JSONSink) WriteBatch(movies []moviedata.Movie) error {
+	timestamp := time.Now().Format("15-04-05")
+	filename := filepath.Join(s.dir, fmt.Sprintf("processed_movies_%s.json", timestamp))
+
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	return encoder.Encode(movies)
+}
+
+func (s *JSONSink) Flush() error { return nil }
+func (s *JSONSink) Close() error { return nil }
@@ -0,0 +1,117 @@
+package sink
+
+// sqlSchema is the relational schema shared by the SQLite and Postgres
+// sinks. Both backends speak standard SQL closely enough that the same
+// DDL works for either, aside from the placeholder style used in upserts.
+const sqlSchema = `
+CREATE TABLE IF NOT EXISTS movies (
+	id                   INTEGER PRIMARY KEY,
+	original_language    TEXT NOT NULL,
+	original_title       TEXT NOT NULL,
+	title                TEXT NOT NULL,
+	poster_path          TEXT,
+	popularity           DOUBLE PRECISION NOT NULL,
+	runtime              INTEGER NOT NULL,
+	budget               INTEGER NOT NULL,
+	release_date         TEXT
+);
+
+CREATE TABLE IF NOT EXISTS countries (
+	iso_3166_1 TEXT PRIMARY KEY,
+	name       TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS movie_countries (
+	movie_id   INTEGER NOT NULL,
+	iso_3166_1 TEXT NOT NULL,
+	PRIMARY KEY (movie_id, iso_3166_1)
+);
+
+CREATE TABLE IF NOT EXISTS genres (
+	id INTEGER PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS movie_genres (
+	movie_id INTEGER NOT NULL,
+	genre_id INTEGER NOT NULL,
+	PRIMARY KEY (movie_id, genre_id)
+);
+
+CREATE TABLE IF NOT EXISTS releases (
+	movie_id  INTEGER NOT NULL,
+	iso_639_1 TEXT NOT NULL,
+	PRIMARY KEY (movie_id, iso_639_1)
+);
+
+CREATE TABLE IF NOT EXISTS local_release_dates (
+	movie_id     INTEGER NOT NULL,
+	iso_639_1    TEXT NOT NULL,
+	note         TEXT NOT NULL,
+	release_date TEXT NOT NULL,
+	type         INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS actors (
+	id   INTEGER PRIMARY KEY,
+	name TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS movie_actors (
+	movie_id INTEGER NOT NULL,
+	actor_id INTEGER NOT NULL,
+	"order"  INTEGER NOT NULL,
+	PRIMARY KEY (movie_id, actor_id)
+);
+
+CREATE TABLE IF NOT EXISTS directors (
+	id   INTEGER PRIMARY KEY,
+	name TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS movie_directors (
+	movie_id    INTEGER NOT NULL,
+	director_id INTEGER NOT NULL,
+	PRIMARY KEY (movie_id, director_id)
+);
+
+-- The tables below hold the sub-resources only populated when the
+-- matching --include flag was passed, so they're empty for a crawl that
+-- didn't request them.
+
+CREATE TABLE IF NOT EXISTS movie_images (
+	movie_id  INTEGER NOT NULL,
+	placement TEXT NOT NULL, -- 'backdrop' or 'poster'
+	file_path TEXT NOT NULL,
+	width     INTEGER NOT NULL,
+	height    INTEGER NOT NULL,
+	iso_639_1 TEXT
+);
+
+CREATE TABLE IF NOT EXISTS movie_videos (
+	movie_id INTEGER NOT NULL,
+	key      TEXT NOT NULL,
+	name     TEXT NOT NULL,
+	site     TEXT NOT NULL,
+	type     TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS movie_keywords (
+	movie_id INTEGER NOT NULL,
+	keyword  TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS movie_external_ids (
+	movie_id     INTEGER PRIMARY KEY,
+	imdb_id      TEXT NOT NULL,
+	wikidata_id  TEXT NOT NULL,
+	facebook_id  TEXT NOT NULL,
+	instagram_id TEXT NOT NULL,
+	twitter_id   TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS movie_alternative_titles (
+	movie_id   INTEGER NOT NULL,
+	iso_3166_1 TEXT NOT NULL,
+	title      TEXT NOT NULL
+);
+`
@@ -0,0 +1,56 @@
+// Package sink abstracts over where processed movie data ends up. The
+// crawler writes batches through a Sink without knowing whether they land
+// as JSON files on disk, rows in SQLite, or rows in Postgres.
+package sink
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pogorskii/tmdb-movies-data/moviedata"
+)
+
+// Sink receives batches of processed movies as the crawler produces them.
+type Sink interface {
+	// WriteBatch persists a batch of movies. The SQL sinks upsert by TMDB
+	// ID; JSONSink writes the batch to a new file.
+	WriteBatch(movies []moviedata.Movie) error
+	// Flush pushes any buffered writes out to the backing store.
+	Flush() error
+	// Close releases resources held by the sink (file handles, DB
+	// connections). The sink must not be used afterwards.
+	Close() error
+}
+
+// New builds the Sink selected by kind ("json", "sqlite", or "postgres").
+// An empty kind falls back to "json" to match the tool's historical
+// behavior. dsn is the sink-specific connection string: a directory for
+// JSON, a file path for SQLite, or a Postgres connection URL.
+func New(kind, dsn string) (Sink, error) {
+	switch kind {
+	case "", "json":
+		dir := dsn
+		if dir == "" {
+			dir = "."
+		}
+		return NewJSONSink(dir), nil
+	case "sqlite":
+		if dsn == "" {
+			dsn = "movies.db"
+		}
+		return NewSQLiteSink(dsn)
+	case "postgres":
+		if dsn == "" {
+			return nil, fmt.Errorf("sink: postgres requires a connection string (SINK_DSN)")
+		}
+		return NewPostgresSink(dsn)
+	default:
+		return nil, fmt.Errorf("sink: unknown sink kind %q", kind)
+	}
+}
+
+// FromEnv builds a Sink from the SINK and SINK_DSN environment variables,
+// matching how the rest of the crawler is configured.
+func FromEnv() (Sink, error) {
+	return New(os.Getenv("SINK"), os.Getenv("SINK_DSN"))
+}
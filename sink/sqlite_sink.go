@@ -0,0 +1,226 @@
+package sink
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/pogorskii/tmdb-movies-data/moviedata"
+)
+
+// SQLiteSink stores processed movies in a local SQLite database, upserting
+// on TMDB ID so reruns update existing rows instead of duplicating them.
+type SQLiteSink struct {
+	db *sql.DB
+}
+
+// NewSQLiteSink opens (creating if necessary) the SQLite database at path.
+func NewSQLiteSink(path string) (*SQLiteSink, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("sink: open sqlite %s: %w", path, err)
+	}
+	if _, err := db.Exec(sqlSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("sink: create sqlite schema: %w", err)
+	}
+	return &SQLiteSink{db: db}, nil
+}
+
+func (s *SQLiteSink) WriteBatch(movies []moviedata.Movie) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("sink: begin sqlite batch: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, movie := range movies {
+		if err := upsertSQLiteMovie(tx, movie); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func upsertSQLiteMovie(tx *sql.Tx, movie moviedata.Movie) error {
+	_, err := tx.Exec(`
+		INSERT INTO movies (id, original_language, original_title, title, poster_path, popularity, runtime, budget, release_date)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			original_language = excluded.original_language,
+			original_title    = excluded.original_title,
+			title             = excluded.title,
+			poster_path       = excluded.poster_path,
+			popularity        = excluded.popularity,
+			runtime           = excluded.runtime,
+			budget            = excluded.budget,
+			release_date      = excluded.release_date`,
+		movie.ID, movie.OriginalLanguage, movie.OriginalTitle, movie.Title,
+		movie.PosterPath, movie.Popularity, movie.Runtime, movie.Budget, movie.ReleaseDate,
+	)
+	if err != nil {
+		return fmt.Errorf("sink: upsert movie %d: %w", movie.ID, err)
+	}
+
+	if err := replaceSQLiteChildren(tx, movie); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// replaceSQLiteChildren clears and reinserts movie.ID's child rows
+// (countries, genres, releases, actors, directors, and any --include
+// sub-resources present on movie) so a rerun reflects TMDB's current data
+// rather than accumulating stale associations.
+func replaceSQLiteChildren(tx *sql.Tx, movie moviedata.Movie) error {
+	for _, stmt := range []string{
+		`DELETE FROM movie_countries WHERE movie_id = ?`,
+		`DELETE FROM movie_genres WHERE movie_id = ?`,
+		`DELETE FROM local_release_dates WHERE movie_id = ?`,
+		`DELETE FROM releases WHERE movie_id = ?`,
+		`DELETE FROM movie_actors WHERE movie_id = ?`,
+		`DELETE FROM movie_directors WHERE movie_id = ?`,
+		`DELETE FROM movie_images WHERE movie_id = ?`,
+		`DELETE FROM movie_videos WHERE movie_id = ?`,
+		`DELETE FROM movie_keywords WHERE movie_id = ?`,
+		`DELETE FROM movie_external_ids WHERE movie_id = ?`,
+		`DELETE FROM movie_alternative_titles WHERE movie_id = ?`,
+	} {
+		if _, err := tx.Exec(stmt, movie.ID); err != nil {
+			return fmt.Errorf("sink: clear children for movie %d: %w", movie.ID, err)
+		}
+	}
+
+	for _, country := range movie.ProductionCountries {
+		_, err := tx.Exec(`
+			INSERT INTO countries (iso_3166_1, name) VALUES (?, ?)
+			ON CONFLICT (iso_3166_1) DO UPDATE SET name = excluded.name`,
+			country.ISO3166_1, country.Name)
+		if err != nil {
+			return fmt.Errorf("sink: upsert country %s: %w", country.ISO3166_1, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO movie_countries (movie_id, iso_3166_1) VALUES (?, ?)`, movie.ID, country.ISO3166_1); err != nil {
+			return fmt.Errorf("sink: link movie %d to country %s: %w", movie.ID, country.ISO3166_1, err)
+		}
+	}
+
+	for _, genreID := range movie.Genres {
+		if _, err := tx.Exec(`INSERT INTO genres (id) VALUES (?) ON CONFLICT (id) DO NOTHING`, genreID); err != nil {
+			return fmt.Errorf("sink: upsert genre %d: %w", genreID, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO movie_genres (movie_id, genre_id) VALUES (?, ?)`, movie.ID, genreID); err != nil {
+			return fmt.Errorf("sink: link movie %d to genre %d: %w", movie.ID, genreID, err)
+		}
+	}
+
+	for _, release := range movie.Releases {
+		if _, err := tx.Exec(`INSERT INTO releases (movie_id, iso_639_1) VALUES (?, ?)`, movie.ID, release.ISO639_1); err != nil {
+			return fmt.Errorf("sink: insert release for movie %d: %w", movie.ID, err)
+		}
+		for _, local := range release.LocalReleaseDates {
+			_, err := tx.Exec(
+				`INSERT INTO local_release_dates (movie_id, iso_639_1, note, release_date, type) VALUES (?, ?, ?, ?, ?)`,
+				movie.ID, release.ISO639_1, local.Note, local.ReleaseDate, local.Type,
+			)
+			if err != nil {
+				return fmt.Errorf("sink: insert local release date for movie %d: %w", movie.ID, err)
+			}
+		}
+	}
+
+	for _, actor := range movie.Actors {
+		_, err := tx.Exec(`
+			INSERT INTO actors (id, name) VALUES (?, ?)
+			ON CONFLICT (id) DO UPDATE SET name = excluded.name`,
+			actor.ID, actor.Name)
+		if err != nil {
+			return fmt.Errorf("sink: upsert actor %d: %w", actor.ID, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO movie_actors (movie_id, actor_id, "order") VALUES (?, ?, ?)`, movie.ID, actor.ID, actor.Order); err != nil {
+			return fmt.Errorf("sink: link movie %d to actor %d: %w", movie.ID, actor.ID, err)
+		}
+	}
+
+	for _, director := range movie.Directors {
+		_, err := tx.Exec(`
+			INSERT INTO directors (id, name) VALUES (?, ?)
+			ON CONFLICT (id) DO UPDATE SET name = excluded.name`,
+			director.ID, director.Name)
+		if err != nil {
+			return fmt.Errorf("sink: upsert director %d: %w", director.ID, err)
+		}
+		if _, err := tx.Exec(`INSERT INTO movie_directors (movie_id, director_id) VALUES (?, ?)`, movie.ID, director.ID); err != nil {
+			return fmt.Errorf("sink: link movie %d to director %d: %w", movie.ID, director.ID, err)
+		}
+	}
+
+	if movie.Images != nil {
+		for _, img := range movie.Images.Backdrops {
+			if err := insertSQLiteImage(tx, movie.ID, "backdrop", img); err != nil {
+				return err
+			}
+		}
+		for _, img := range movie.Images.Posters {
+			if err := insertSQLiteImage(tx, movie.ID, "poster", img); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, video := range movie.Videos {
+		_, err := tx.Exec(
+			`INSERT INTO movie_videos (movie_id, key, name, site, type) VALUES (?, ?, ?, ?, ?)`,
+			movie.ID, video.Key, video.Name, video.Site, video.Type,
+		)
+		if err != nil {
+			return fmt.Errorf("sink: insert video for movie %d: %w", movie.ID, err)
+		}
+	}
+
+	for _, keyword := range movie.Keywords {
+		if _, err := tx.Exec(`INSERT INTO movie_keywords (movie_id, keyword) VALUES (?, ?)`, movie.ID, keyword); err != nil {
+			return fmt.Errorf("sink: insert keyword for movie %d: %w", movie.ID, err)
+		}
+	}
+
+	if movie.ExternalIDs != nil {
+		_, err := tx.Exec(
+			`INSERT INTO movie_external_ids (movie_id, imdb_id, wikidata_id, facebook_id, instagram_id, twitter_id) VALUES (?, ?, ?, ?, ?, ?)`,
+			movie.ID, movie.ExternalIDs.IMDbID, movie.ExternalIDs.WikidataID, movie.ExternalIDs.FacebookID, movie.ExternalIDs.InstagramID, movie.ExternalIDs.TwitterID,
+		)
+		if err != nil {
+			return fmt.Errorf("sink: insert external IDs for movie %d: %w", movie.ID, err)
+		}
+	}
+
+	for _, title := range movie.AlternativeTitles {
+		_, err := tx.Exec(
+			`INSERT INTO movie_alternative_titles (movie_id, iso_3166_1, title) VALUES (?, ?, ?)`,
+			movie.ID, title.ISO3166_1, title.Title,
+		)
+		if err != nil {
+			return fmt.Errorf("sink: insert alternative title for movie %d: %w", movie.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// insertSQLiteImage inserts a single image row under the given placement
+// ("backdrop" or "poster").
+func insertSQLiteImage(tx *sql.Tx, movieID int, placement string, img moviedata.Image) error {
+	_, err := tx.Exec(
+		`INSERT INTO movie_images (movie_id, placement, file_path, width, height, iso_639_1) VALUES (?, ?, ?, ?, ?, ?)`,
+		movieID, placement, img.FilePath, img.Width, img.Height, img.ISO639_1,
+	)
+	if err != nil {
+		return fmt.Errorf("sink: insert %s image for movie %d: %w", placement, movieID, err)
+	}
+	return nil
+}
+
+func (s *SQLiteSink) Flush() error { return nil }
+func (s *SQLiteSink) Close() error { return s.db.Close() }
@@ -0,0 +1,263 @@
+package main
+
+import "github.com/pogorskii/tmdb-movies-data/moviedata"
+
+// tmdbMovieResponse mirrors the fields of GET /3/movie/{id} that this tool
+// cares about, including the release_dates and credits sub-resources
+// pulled in via append_to_response. Fields TMDB can send as null use
+// pointers so a missing value decodes to nil instead of panicking.
+type tmdbMovieResponse struct {
+	ID                  int              `json:"id"`
+	OriginalLanguage    string           `json:"original_language"`
+	OriginalTitle       string           `json:"original_title"`
+	Title               string           `json:"title"`
+	PosterPath          *string          `json:"poster_path"`
+	Popularity          float64          `json:"popularity"`
+	Runtime             *int             `json:"runtime"`
+	Budget              int              `json:"budget"`
+	ReleaseDate         string           `json:"release_date"`
+	Genres              []tmdbGenre      `json:"genres"`
+	ProductionCountries []tmdbCountry    `json:"production_countries"`
+	ReleaseDates        tmdbReleaseDates `json:"release_dates"`
+	Credits             tmdbCredits      `json:"credits"`
+
+	// The fields below are only present in the response when the
+	// matching sub-resource was requested via append_to_response; a
+	// nil pointer/slice means it wasn't asked for, not that TMDB has no
+	// data for it.
+	Images            *tmdbImages            `json:"images"`
+	Videos            *tmdbVideos            `json:"videos"`
+	Keywords          *tmdbKeywords          `json:"keywords"`
+	ExternalIDs       *tmdbExternalIDs       `json:"external_ids"`
+	AlternativeTitles *tmdbAlternativeTitles `json:"alternative_titles"`
+}
+
+type tmdbGenre struct {
+	ID int `json:"id"`
+}
+
+type tmdbCountry struct {
+	ISO3166_1 string `json:"iso_3166_1"`
+	Name      string `json:"name"`
+}
+
+// tmdbReleaseDates is the body of the release_dates sub-resource, keyed
+// per-country under "results".
+type tmdbReleaseDates struct {
+	Results []tmdbReleaseDatesResult `json:"results"`
+}
+
+type tmdbReleaseDatesResult struct {
+	ISO3166_1    string                  `json:"iso_3166_1"`
+	ReleaseDates []tmdbLocalReleaseDate `json:"release_dates"`
+}
+
+type tmdbLocalReleaseDate struct {
+	Note        string `json:"note"`
+	ReleaseDate string `json:"release_date"`
+	Type        int    `json:"type"`
+}
+
+// tmdbCredits is the body of the credits sub-resource.
+type tmdbCredits struct {
+	Cast []tmdbCastMember `json:"cast"`
+	Crew []tmdbCrewMember `json:"crew"`
+}
+
+type tmdbCastMember struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Order int    `json:"order"`
+}
+
+type tmdbCrewMember struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Job  string `json:"job"`
+}
+
+// maxBilledActors caps how many cast members are kept per movie, matching
+// the tool's historical "top-billed only" behavior.
+const maxBilledActors = 5
+
+// tmdbImages is the body of the images sub-resource.
+type tmdbImages struct {
+	Backdrops []tmdbImage `json:"backdrops"`
+	Posters   []tmdbImage `json:"posters"`
+}
+
+type tmdbImage struct {
+	FilePath string  `json:"file_path"`
+	Width    int     `json:"width"`
+	Height   int     `json:"height"`
+	ISO639_1 *string `json:"iso_639_1"`
+}
+
+// tmdbVideos is the body of the videos sub-resource.
+type tmdbVideos struct {
+	Results []tmdbVideo `json:"results"`
+}
+
+type tmdbVideo struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Site string `json:"site"`
+	Type string `json:"type"`
+}
+
+// tmdbKeywords is the body of the keywords sub-resource.
+type tmdbKeywords struct {
+	Keywords []tmdbKeyword `json:"keywords"`
+}
+
+type tmdbKeyword struct {
+	Name string `json:"name"`
+}
+
+// tmdbExternalIDs is the body of the external_ids sub-resource.
+type tmdbExternalIDs struct {
+	IMDbID      string `json:"imdb_id"`
+	WikidataID  string `json:"wikidata_id"`
+	FacebookID  string `json:"facebook_id"`
+	InstagramID string `json:"instagram_id"`
+	TwitterID   string `json:"twitter_id"`
+}
+
+// tmdbAlternativeTitles is the body of the alternative_titles sub-resource.
+type tmdbAlternativeTitles struct {
+	Titles []tmdbAlternativeTitle `json:"titles"`
+}
+
+type tmdbAlternativeTitle struct {
+	ISO3166_1 string `json:"iso_3166_1"`
+	Title     string `json:"title"`
+}
+
+// toMovie maps a decoded TMDB response onto the tool's public Movie type.
+func (r tmdbMovieResponse) toMovie() moviedata.Movie {
+	movie := moviedata.Movie{
+		ID:                  r.ID,
+		OriginalLanguage:    r.OriginalLanguage,
+		OriginalTitle:       r.OriginalTitle,
+		Title:               r.Title,
+		PosterPath:          r.PosterPath,
+		Popularity:          r.Popularity,
+		Budget:              r.Budget,
+		ReleaseDate:         r.ReleaseDate,
+		Genres:              make([]int, 0, len(r.Genres)),
+		ProductionCountries: make([]moviedata.Country, 0, len(r.ProductionCountries)),
+	}
+
+	if r.Runtime != nil {
+		movie.Runtime = *r.Runtime
+	}
+
+	for _, genre := range r.Genres {
+		movie.Genres = append(movie.Genres, genre.ID)
+	}
+
+	for _, country := range r.ProductionCountries {
+		movie.ProductionCountries = append(movie.ProductionCountries, moviedata.Country{
+			ISO3166_1: country.ISO3166_1,
+			Name:      country.Name,
+		})
+	}
+
+	movie.Releases = make([]moviedata.Release, 0, len(r.ReleaseDates.Results))
+	for _, result := range r.ReleaseDates.Results {
+		localReleaseDates := make([]moviedata.LocalReleaseDate, 0, len(result.ReleaseDates))
+		for _, local := range result.ReleaseDates {
+			localReleaseDates = append(localReleaseDates, moviedata.LocalReleaseDate{
+				Note:        local.Note,
+				ReleaseDate: local.ReleaseDate,
+				Type:        local.Type,
+			})
+		}
+		movie.Releases = append(movie.Releases, moviedata.Release{
+			ISO639_1:          result.ISO3166_1,
+			LocalReleaseDates: localReleaseDates,
+		})
+	}
+
+	for _, cast := range r.Credits.Cast {
+		if cast.Order >= maxBilledActors {
+			continue
+		}
+		movie.Actors = append(movie.Actors, moviedata.Actor{
+			ID:    cast.ID,
+			Name:  cast.Name,
+			Order: cast.Order,
+		})
+	}
+
+	for _, crew := range r.Credits.Crew {
+		if crew.Job != "Director" {
+			continue
+		}
+		movie.Directors = append(movie.Directors, moviedata.Director{
+			ID:   crew.ID,
+			Name: crew.Name,
+		})
+	}
+
+	if r.Images != nil {
+		movie.Images = &moviedata.Images{
+			Backdrops: toMovieImages(r.Images.Backdrops),
+			Posters:   toMovieImages(r.Images.Posters),
+		}
+	}
+
+	if r.Videos != nil {
+		movie.Videos = make([]moviedata.Video, 0, len(r.Videos.Results))
+		for _, video := range r.Videos.Results {
+			movie.Videos = append(movie.Videos, moviedata.Video{
+				Key:  video.Key,
+				Name: video.Name,
+				Site: video.Site,
+				Type: video.Type,
+			})
+		}
+	}
+
+	if r.Keywords != nil {
+		movie.Keywords = make([]string, 0, len(r.Keywords.Keywords))
+		for _, keyword := range r.Keywords.Keywords {
+			movie.Keywords = append(movie.Keywords, keyword.Name)
+		}
+	}
+
+	if r.ExternalIDs != nil {
+		movie.ExternalIDs = &moviedata.ExternalIDs{
+			IMDbID:      r.ExternalIDs.IMDbID,
+			WikidataID:  r.ExternalIDs.WikidataID,
+			FacebookID:  r.ExternalIDs.FacebookID,
+			InstagramID: r.ExternalIDs.InstagramID,
+			TwitterID:   r.ExternalIDs.TwitterID,
+		}
+	}
+
+	if r.AlternativeTitles != nil {
+		movie.AlternativeTitles = make([]moviedata.AlternativeTitle, 0, len(r.AlternativeTitles.Titles))
+		for _, title := range r.AlternativeTitles.Titles {
+			movie.AlternativeTitles = append(movie.AlternativeTitles, moviedata.AlternativeTitle{
+				ISO3166_1: title.ISO3166_1,
+				Title:     title.Title,
+			})
+		}
+	}
+
+	return movie
+}
+
+func toMovieImages(images []tmdbImage) []moviedata.Image {
+	out := make([]moviedata.Image, 0, len(images))
+	for _, img := range images {
+		out = append(out, moviedata.Image{
+			FilePath: img.FilePath,
+			Width:    img.Width,
+			Height:   img.Height,
+			ISO639_1: img.ISO639_1,
+		})
+	}
+	return out
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestTMDBMovieResponseToMovie(t *testing.T) {
+	tests := []struct {
+		name           string
+		fixture        string
+		wantRuntime    int
+		wantPoster     *string
+		wantActorCount int
+		wantDirectors  int
+		wantGenres     []int
+	}{
+		{
+			name:           "full response with credits and release dates",
+			fixture:        "testdata/movie_full.json",
+			wantRuntime:    136,
+			wantPoster:     strPtr("/f89U3ADr1oiB1s9GkdPOEpXUk5H.jpg"),
+			wantActorCount: 5, // only the top 5 billed cast members are kept
+			wantDirectors:  2,
+			wantGenres:     []int{28, 878},
+		},
+		{
+			name:           "minimal response with null poster and runtime",
+			fixture:        "testdata/movie_minimal.json",
+			wantRuntime:    0,
+			wantPoster:     nil,
+			wantActorCount: 0,
+			wantDirectors:  0,
+			wantGenres:     []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := os.ReadFile(tt.fixture)
+			if err != nil {
+				t.Fatalf("reading fixture: %v", err)
+			}
+
+			var resp tmdbMovieResponse
+			if err := json.Unmarshal(body, &resp); err != nil {
+				t.Fatalf("unmarshalling fixture: %v", err)
+			}
+
+			movie := resp.toMovie()
+
+			if movie.Runtime != tt.wantRuntime {
+				t.Errorf("Runtime = %d, want %d", movie.Runtime, tt.wantRuntime)
+			}
+			switch {
+			case movie.PosterPath == nil && tt.wantPoster != nil:
+				t.Errorf("PosterPath = nil, want %v", *tt.wantPoster)
+			case movie.PosterPath != nil && tt.wantPoster == nil:
+				t.Errorf("PosterPath = %v, want nil", *movie.PosterPath)
+			case movie.PosterPath != nil && tt.wantPoster != nil && *movie.PosterPath != *tt.wantPoster:
+				t.Errorf("PosterPath = %v, want %v", *movie.PosterPath, *tt.wantPoster)
+			}
+			if len(movie.Actors) != tt.wantActorCount {
+				t.Errorf("len(Actors) = %d, want %d", len(movie.Actors), tt.wantActorCount)
+			}
+			if len(movie.Directors) != tt.wantDirectors {
+				t.Errorf("len(Directors) = %d, want %d", len(movie.Directors), tt.wantDirectors)
+			}
+			if len(movie.Genres) != len(tt.wantGenres) {
+				t.Fatalf("len(Genres) = %d, want %d", len(movie.Genres), len(tt.wantGenres))
+			}
+			for i, id := range tt.wantGenres {
+				if movie.Genres[i] != id {
+					t.Errorf("Genres[%d] = %d, want %d", i, movie.Genres[i], id)
+				}
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }